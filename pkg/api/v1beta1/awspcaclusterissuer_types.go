@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AWSPCAClusterIssuer is the cluster-scoped equivalent of AWSPCAIssuer. It
+// has no namespace of its own, so SecretRef.Namespace must be set to locate
+// its credentials Secret.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:webhook:path=/validate-awspca-cert-manager-io-v1beta1-awspcaclusterissuer,mutating=false,failurePolicy=fail,sideEffects=None,groups=awspca.cert-manager.io,resources=awspcaclusterissuers,verbs=create;update,versions=v1beta1,name=validate-awspcaclusterissuer.cert-manager.io,admissionReviewVersions=v1
+type AWSPCAClusterIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSPCAIssuerSpec   `json:"spec,omitempty"`
+	Status AWSPCAIssuerStatus `json:"status,omitempty"`
+}
+
+// GetSpec returns a pointer to the Issuer's spec, satisfying GenericIssuer.
+func (a *AWSPCAClusterIssuer) GetSpec() *AWSPCAIssuerSpec { return &a.Spec }
+
+// GetStatus returns a pointer to the Issuer's status, satisfying GenericIssuer.
+func (a *AWSPCAClusterIssuer) GetStatus() *AWSPCAIssuerStatus { return &a.Status }
+
+// +kubebuilder:object:root=true
+type AWSPCAClusterIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSPCAClusterIssuer `json:"items"`
+}
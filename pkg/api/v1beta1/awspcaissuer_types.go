@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConditionType represents an Issuer condition type
+type ConditionType string
+
+const (
+	// ConditionTypeReady represents the fact that a given Issuer condition
+	// is in ready state.
+	ConditionTypeReady ConditionType = "Ready"
+)
+
+// SecretReference refers to the Kubernetes Secret holding the credentials
+// used to authenticate against AWS. Namespace is only honored on
+// AWSPCAClusterIssuer, which is cluster-scoped and therefore cannot infer it
+// from its own object metadata.
+type SecretReference struct {
+	// Name of the secret resource being referred to.
+	Name string `json:"name"`
+
+	// Namespace of the secret resource being referred to, required for
+	// AWSPCAClusterIssuer.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AWSPCAIssuerSpec defines the desired state of AWSPCAIssuer and
+// AWSPCAClusterIssuer.
+type AWSPCAIssuerSpec struct {
+	// Arn is the Amazon Resource Name of the PCA that will sign
+	// certificate requests
+	Arn string `json:"arn"`
+
+	// Region is the region the PCA is located in
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// SecretRef is a reference to a Secret containing static AWS
+	// credentials (AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY). It may be
+	// left empty to fall back to ambient credentials, such as IRSA or an
+	// instance profile, but only when AllowAmbientCredentials is true.
+	// +optional
+	SecretRef SecretReference `json:"secretRef,omitempty"`
+
+	// AllowAmbientCredentials must be set to true for SecretRef and
+	// CredentialsSource to both be left empty, opting the Issuer into
+	// ambient credentials (IRSA or an instance profile) picked up by the
+	// default AWS SDK credential chain. This is a deliberate opt-in so
+	// that a typo'd or missing SecretRef does not silently fall back to
+	// whatever credentials the controller pod happens to carry.
+	// +optional
+	AllowAmbientCredentials bool `json:"allowAmbientCredentials,omitempty"`
+
+	// AssumeRoleArn is the Amazon Resource Name of an IAM Role to assume,
+	// via STS, before signing requests against the PCA. This is useful
+	// when the PCA lives in a different AWS account than the credentials
+	// resolved from SecretRef or the ambient environment (e.g. IRSA).
+	// +optional
+	AssumeRoleArn string `json:"assumeRoleArn,omitempty"`
+
+	// ExternalID is passed to sts:AssumeRole when AssumeRoleArn is set,
+	// for roles that require it to protect against the confused deputy
+	// problem.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// SessionName is passed to sts:AssumeRole when AssumeRoleArn is set.
+	// Defaults to "aws-pca-issuer" when empty.
+	// +optional
+	SessionName string `json:"sessionName,omitempty"`
+
+	// Endpoint overrides the default ACM-PCA service endpoint URL, for
+	// PrivateLink VPC endpoints or testing against a local AWS API
+	// emulator.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// UseFIPSEndpoint routes ACM-PCA calls to the AWS FIPS 140-2 validated
+	// endpoint for the configured region.
+	// +optional
+	UseFIPSEndpoint bool `json:"useFIPSEndpoint,omitempty"`
+
+	// UseDualStackEndpoint routes ACM-PCA calls to the region's dual-stack
+	// (IPv4/IPv6) endpoint.
+	// +optional
+	UseDualStackEndpoint bool `json:"useDualStackEndpoint,omitempty"`
+
+	// DisableSSL disables TLS when calling ACM-PCA. This should only ever
+	// be used against a local AWS API emulator such as LocalStack.
+	// +optional
+	DisableSSL bool `json:"disableSSL,omitempty"`
+
+	// CredentialsSource fetches the signing credentials from AWS Secrets
+	// Manager or SSM Parameter Store instead of a Kubernetes Secret, so
+	// that long-lived AWS keys never need to be placed in the cluster.
+	// Ignored when SecretRef is also set, as SecretRef takes precedence;
+	// when both are empty the reconciler falls back to ambient
+	// credentials, provided AllowAmbientCredentials is true.
+	// +optional
+	CredentialsSource *CredentialsSource `json:"credentialsSource,omitempty"`
+}
+
+// CredentialsSource points at AWS-managed storage for the
+// AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY pair used to sign PCA requests.
+// Exactly one of SecretsManagerSecretArn or SSMParameterName should be set.
+type CredentialsSource struct {
+	// SecretsManagerSecretArn is the ARN of a Secrets Manager secret whose
+	// JSON value has AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	// +optional
+	SecretsManagerSecretArn string `json:"secretsManagerSecretArn,omitempty"`
+
+	// SSMParameterName is the name of a SSM SecureString parameter whose
+	// JSON value has AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	// +optional
+	SSMParameterName string `json:"ssmParameterName,omitempty"`
+
+	// RefreshInterval controls how long a fetched credential is cached
+	// before CredentialsSource is queried again. Defaults to 5 minutes.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// AWSPCAIssuerStatus defines the observed state of AWSPCAIssuer and
+// AWSPCAClusterIssuer.
+type AWSPCAIssuerStatus struct {
+	// Conditions is a list of status conditions for this Issuer.
+	// +optional
+	Conditions []AWSPCAIssuerCondition `json:"conditions,omitempty"`
+
+	// CredentialsGeneration is the resourceVersion of the SecretRef that
+	// was last used to build the cached Provisioner for this Issuer. It
+	// lets operators confirm that a credential rotation has been picked
+	// up without having to restart the controller.
+	// +optional
+	CredentialsGeneration string `json:"credentialsGeneration,omitempty"`
+
+	// Endpoint is the effective ACM-PCA endpoint URL this Issuer is
+	// signing against, resolved from spec.Endpoint and the SDK defaults
+	// for the configured region.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// AWSPCAIssuerCondition describes the state of an Issuer at a certain point.
+type AWSPCAIssuerCondition struct {
+	// Type of the condition
+	Type ConditionType `json:"type"`
+
+	// Status of the condition
+	Status metav1.ConditionStatus `json:"status"`
+
+	// Reason is a brief machine readable explanation for the condition's
+	// last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the
+	// condition's last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GenericIssuer is implemented by both AWSPCAIssuer and AWSPCAClusterIssuer
+// so that GenericIssuerReconciler can reconcile either kind.
+type GenericIssuer interface {
+	metav1.Object
+	runtime.Object
+
+	GetSpec() *AWSPCAIssuerSpec
+	GetStatus() *AWSPCAIssuerStatus
+}
+
+// AWSPCAIssuer is a namespaced Issuer that signs CertificateRequests
+// through AWS Certificate Manager Private CA.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:webhook:path=/validate-awspca-cert-manager-io-v1beta1-awspcaissuer,mutating=false,failurePolicy=fail,sideEffects=None,groups=awspca.cert-manager.io,resources=awspcaissuers,verbs=create;update,versions=v1beta1,name=validate-awspcaissuer.cert-manager.io,admissionReviewVersions=v1
+type AWSPCAIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSPCAIssuerSpec   `json:"spec,omitempty"`
+	Status AWSPCAIssuerStatus `json:"status,omitempty"`
+}
+
+// GetSpec returns a pointer to the Issuer's spec, satisfying GenericIssuer.
+func (a *AWSPCAIssuer) GetSpec() *AWSPCAIssuerSpec { return &a.Spec }
+
+// GetStatus returns a pointer to the Issuer's status, satisfying GenericIssuer.
+func (a *AWSPCAIssuer) GetStatus() *AWSPCAIssuerStatus { return &a.Status }
+
+// +kubebuilder:object:root=true
+type AWSPCAIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSPCAIssuer `json:"items"`
+}
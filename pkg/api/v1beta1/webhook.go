@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the AWSPCAIssuer validating webhook.
+func (a *AWSPCAIssuer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(a).Complete()
+}
+
+// SetupWebhookWithManager registers the AWSPCAClusterIssuer validating webhook.
+func (a *AWSPCAClusterIssuer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(a).Complete()
+}
+
+// pcaArnPattern matches an ACM-PCA certificate authority ARN, e.g.
+// arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/0f1ea122-...
+var pcaArnPattern = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:acm-pca:([a-z0-9-]+):[0-9]{12}:certificate-authority/[0-9a-fA-F-]{36}$`)
+
+// ValidateCreate implements webhook.Validator so the API server rejects a
+// malformed AWSPCAIssuer at admission time instead of leaving it stuck in
+// Ready=False.
+func (a *AWSPCAIssuer) ValidateCreate() (admission.Warnings, error) {
+	return nil, validateIssuerSpec(&a.Spec, false)
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (a *AWSPCAIssuer) ValidateUpdate(runtime.Object) (admission.Warnings, error) {
+	return nil, validateIssuerSpec(&a.Spec, false)
+}
+
+// ValidateDelete implements webhook.Validator. Deletes are always allowed.
+func (a *AWSPCAIssuer) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateCreate implements webhook.Validator so the API server rejects a
+// malformed AWSPCAClusterIssuer at admission time instead of leaving it
+// stuck in Ready=False.
+func (a *AWSPCAClusterIssuer) ValidateCreate() (admission.Warnings, error) {
+	return nil, validateIssuerSpec(&a.Spec, true)
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (a *AWSPCAClusterIssuer) ValidateUpdate(runtime.Object) (admission.Warnings, error) {
+	return nil, validateIssuerSpec(&a.Spec, true)
+}
+
+// ValidateDelete implements webhook.Validator. Deletes are always allowed.
+func (a *AWSPCAClusterIssuer) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateIssuerSpec performs the admission-time checks that used to only
+// happen at reconcile time via validateIssuer in the controllers package:
+// well-formed Arn, Arn/Region agreement, and a SecretRef that can actually
+// be resolved for the scope of Issuer being validated.
+func validateIssuerSpec(spec *AWSPCAIssuerSpec, cluster bool) error {
+	match := pcaArnPattern.FindStringSubmatch(spec.Arn)
+	if match == nil {
+		return fmt.Errorf("arn %q is not a well-formed ACM-PCA certificate authority ARN", spec.Arn)
+	}
+
+	arnRegion := match[1]
+	if spec.Region != "" && spec.Region != arnRegion {
+		return fmt.Errorf("region %q in spec does not match region %q in arn", spec.Region, arnRegion)
+	}
+
+	if spec.SecretRef.Name == "" && spec.CredentialsSource == nil && !spec.AllowAmbientCredentials {
+		return fmt.Errorf("secretRef or credentialsSource must be set, or allowAmbientCredentials must be true to opt into ambient credentials")
+	}
+
+	if cluster && spec.SecretRef.Name != "" && spec.SecretRef.Namespace == "" {
+		return fmt.Errorf("secretRef.namespace is required for AWSPCAClusterIssuer")
+	}
+
+	return nil
+}
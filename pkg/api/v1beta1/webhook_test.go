@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "testing"
+
+func TestValidateIssuerSpec(t *testing.T) {
+	validArn := "arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"
+
+	tests := map[string]struct {
+		spec    AWSPCAIssuerSpec
+		cluster bool
+		wantErr bool
+	}{
+		"valid namespaced issuer": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, SecretRef: SecretReference{Name: "creds"}},
+			wantErr: false,
+		},
+		"malformed arn": {
+			spec:    AWSPCAIssuerSpec{Arn: "not-an-arn", SecretRef: SecretReference{Name: "creds"}},
+			wantErr: true,
+		},
+		"region mismatch between spec and arn": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, Region: "eu-west-1", SecretRef: SecretReference{Name: "creds"}},
+			wantErr: true,
+		},
+		"region matches arn": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, Region: "us-east-1", SecretRef: SecretReference{Name: "creds"}},
+			wantErr: false,
+		},
+		"no secretRef, no credentialsSource, no ambient opt-in": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn},
+			wantErr: true,
+		},
+		"no secretRef but ambient opt-in": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, AllowAmbientCredentials: true},
+			wantErr: false,
+		},
+		"no secretRef but credentialsSource": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, CredentialsSource: &CredentialsSource{SecretsManagerSecretArn: "arn:aws:secretsmanager:us-east-1:123456789012:secret:foo"}},
+			wantErr: false,
+		},
+		"cluster issuer secretRef missing namespace": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, SecretRef: SecretReference{Name: "creds"}},
+			cluster: true,
+			wantErr: true,
+		},
+		"cluster issuer secretRef with namespace": {
+			spec:    AWSPCAIssuerSpec{Arn: validArn, SecretRef: SecretReference{Name: "creds", Namespace: "pca"}},
+			cluster: true,
+			wantErr: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateIssuerSpec(&tc.spec, tc.cluster)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateIssuerSpec() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// DefaultCredentialsSourceRefresh is how long a credential fetched from
+// Secrets Manager or SSM Parameter Store is cached before being re-fetched.
+const DefaultCredentialsSourceRefresh = 5 * time.Minute
+
+// FetchedCredentials is the AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY pair
+// retrieved from a CredentialsSource.
+type FetchedCredentials struct {
+	AccessKeyID     string `json:"AWS_ACCESS_KEY_ID"`
+	SecretAccessKey string `json:"AWS_SECRET_ACCESS_KEY"`
+}
+
+type cacheEntry struct {
+	creds     FetchedCredentials
+	fetchedAt time.Time
+}
+
+var (
+	credentialsSourceCache = map[string]cacheEntry{}
+	credentialsSourceMutex sync.Mutex
+)
+
+// FetchCredentialsFromSecretsManager retrieves and caches an
+// AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY pair stored as the JSON secret
+// value of the Secrets Manager secret identified by arn. sess must already
+// be authenticated (pod identity, instance profile, or a bootstrap
+// SecretRef) to call Secrets Manager itself.
+func FetchCredentialsFromSecretsManager(sess *session.Session, arn string, refresh time.Duration) (FetchedCredentials, error) {
+	return fetchCached("secretsmanager:"+arn, refresh, func() (FetchedCredentials, error) {
+		out, err := secretsmanager.New(sess).GetSecretValue(&secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(arn),
+		})
+		if err != nil {
+			return FetchedCredentials{}, fmt.Errorf("fetching secret %s from Secrets Manager: %w", arn, err)
+		}
+		return unmarshalCredentials(aws.StringValue(out.SecretString))
+	})
+}
+
+// FetchCredentialsFromSSM retrieves and caches an AWS_ACCESS_KEY_ID /
+// AWS_SECRET_ACCESS_KEY pair stored as the JSON value of the SSM
+// SecureString parameter identified by name. sess must already be
+// authenticated to call SSM itself.
+func FetchCredentialsFromSSM(sess *session.Session, name string, refresh time.Duration) (FetchedCredentials, error) {
+	return fetchCached("ssm:"+name, refresh, func() (FetchedCredentials, error) {
+		out, err := ssm.New(sess).GetParameter(&ssm.GetParameterInput{
+			Name:           aws.String(name),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return FetchedCredentials{}, fmt.Errorf("fetching parameter %s from SSM: %w", name, err)
+		}
+		return unmarshalCredentials(aws.StringValue(out.Parameter.Value))
+	})
+}
+
+func fetchCached(key string, refresh time.Duration, fetch func() (FetchedCredentials, error)) (FetchedCredentials, error) {
+	if refresh <= 0 {
+		refresh = DefaultCredentialsSourceRefresh
+	}
+
+	credentialsSourceMutex.Lock()
+	if entry, ok := credentialsSourceCache[key]; ok && time.Since(entry.fetchedAt) < refresh {
+		credentialsSourceMutex.Unlock()
+		return entry.creds, nil
+	}
+	credentialsSourceMutex.Unlock()
+
+	creds, err := fetch()
+	if err != nil {
+		return FetchedCredentials{}, err
+	}
+
+	credentialsSourceMutex.Lock()
+	credentialsSourceCache[key] = cacheEntry{creds: creds, fetchedAt: time.Now()}
+	credentialsSourceMutex.Unlock()
+
+	return creds, nil
+}
+
+func unmarshalCredentials(raw string) (FetchedCredentials, error) {
+	var creds FetchedCredentials
+	if err := json.Unmarshal([]byte(raw), &creds); err != nil {
+		return FetchedCredentials{}, fmt.Errorf("decoding credentials JSON: %w", err)
+	}
+	if creds.AccessKeyID == "" {
+		return FetchedCredentials{}, fmt.Errorf("AWS_ACCESS_KEY_ID missing from fetched credentials")
+	}
+	if creds.SecretAccessKey == "" {
+		return FetchedCredentials{}, fmt.Errorf("AWS_SECRET_ACCESS_KEY missing from fetched credentials")
+	}
+	return creds, nil
+}
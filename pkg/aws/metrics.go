@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acmpca"
+	"github.com/jniebuhr/aws-pca-issuer/pkg/metrics"
+)
+
+// instrumentPCACall records PCARequestTotal and PCARequestDuration for a
+// single ACM-PCA API call of the given operation (e.g. "IssueCertificate",
+// "GetCertificate"). It should wrap every pcaClient call the Provisioner
+// makes so that throttling, AccessDenied and LimitExceeded errors show up
+// on the controller's existing /metrics endpoint.
+func instrumentPCACall(operation string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	metrics.PCARequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	metrics.PCARequestTotal.WithLabelValues(operation, errorCode(err)).Inc()
+	return err
+}
+
+// errorCode extracts the AWS error code from err, or "" on success.
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+	return "Unknown"
+}
+
+// isRequestInProgress reports whether err is ACM-PCA's
+// RequestInProgressException, returned by GetCertificate while a
+// certificate is still being issued.
+func isRequestInProgress(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == acmpca.ErrCodeRequestInProgressException
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acmpca"
+	"github.com/jniebuhr/aws-pca-issuer/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestErrorCode(t *testing.T) {
+	if got := errorCode(nil); got != "" {
+		t.Errorf("errorCode(nil) = %q, want \"\"", got)
+	}
+
+	if got := errorCode(errors.New("boom")); got != "Unknown" {
+		t.Errorf("errorCode(plain error) = %q, want Unknown", got)
+	}
+
+	awsErr := awserr.New(acmpca.ErrCodeLimitExceededException, "too many requests", nil)
+	if got := errorCode(awsErr); got != acmpca.ErrCodeLimitExceededException {
+		t.Errorf("errorCode(awsErr) = %q, want %q", got, acmpca.ErrCodeLimitExceededException)
+	}
+}
+
+func TestIsRequestInProgress(t *testing.T) {
+	if isRequestInProgress(errors.New("boom")) {
+		t.Error("isRequestInProgress(plain error) = true, want false")
+	}
+
+	inProgress := awserr.New(acmpca.ErrCodeRequestInProgressException, "still issuing", nil)
+	if !isRequestInProgress(inProgress) {
+		t.Error("isRequestInProgress(RequestInProgressException) = false, want true")
+	}
+
+	other := awserr.New(acmpca.ErrCodeLimitExceededException, "too many requests", nil)
+	if isRequestInProgress(other) {
+		t.Error("isRequestInProgress(LimitExceededException) = true, want false")
+	}
+}
+
+func TestInstrumentPCACallRecordsErrorCode(t *testing.T) {
+	wantErr := awserr.New(acmpca.ErrCodeRequestFailedException, "slow down", nil)
+	counter := metrics.PCARequestTotal.WithLabelValues("IssueCertificate", acmpca.ErrCodeRequestFailedException)
+	before := testutil.ToFloat64(counter)
+
+	err := instrumentPCACall("IssueCertificate", func() error {
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("instrumentPCACall() error = %v, want %v", err, wantErr)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("PCARequestTotal{IssueCertificate,%s} increased by %v, want 1", acmpca.ErrCodeRequestFailedException, got)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aws contains the ACM-PCA Provisioner used to sign
+// CertificateRequests, and the in-memory store that maps an Issuer to its
+// Provisioner.
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acmpca"
+	"github.com/jniebuhr/aws-pca-issuer/pkg/metrics"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// certificatePollInterval is how often Sign polls GetCertificate while
+// waiting for ACM-PCA to finish issuing a certificate.
+const certificatePollInterval = 2 * time.Second
+
+// Provisioner signs CertificateRequests against a single ACM-PCA
+// certificate authority.
+type Provisioner struct {
+	pcaClient *acmpca.ACMPCA
+	arn       string
+
+	// secretResourceVersion is the resourceVersion of the credentials
+	// Secret this Provisioner was built from, if any, as reported by
+	// SecretResourceVersion. StoreProvisioner does not compare it: every
+	// call replaces the stored Provisioner unconditionally.
+	secretResourceVersion string
+}
+
+// NewProvisioner creates a Provisioner that signs against the PCA
+// identified by arn, using sess for authentication.
+func NewProvisioner(sess *session.Session, arn string) *Provisioner {
+	return &Provisioner{
+		pcaClient: acmpca.New(sess),
+		arn:       arn,
+	}
+}
+
+// WithSecretResourceVersion records the resourceVersion of the credentials
+// Secret this Provisioner was built from, and returns the Provisioner for
+// chaining.
+func (p *Provisioner) WithSecretResourceVersion(resourceVersion string) *Provisioner {
+	p.secretResourceVersion = resourceVersion
+	return p
+}
+
+// SecretResourceVersion returns the resourceVersion of the credentials
+// Secret this Provisioner was built from, or "" if it was not built from a
+// Secret.
+func (p *Provisioner) SecretResourceVersion() string {
+	return p.secretResourceVersion
+}
+
+// Sign submits csr to ACM-PCA for signing with the given validity and
+// signing algorithm, waits for ACM-PCA to finish issuing it, and returns
+// the PEM-encoded issued certificate chain. Every ACM-PCA call it makes is
+// instrumented via instrumentPCACall.
+func (p *Provisioner) Sign(ctx context.Context, csr []byte, duration time.Duration, signingAlgorithm string) ([]byte, error) {
+	var issueOut *acmpca.IssueCertificateOutput
+	err := instrumentPCACall("IssueCertificate", func() error {
+		var err error
+		issueOut, err = p.pcaClient.IssueCertificateWithContext(ctx, &acmpca.IssueCertificateInput{
+			CertificateAuthorityArn: aws.String(p.arn),
+			Csr:                     csr,
+			SigningAlgorithm:        aws.String(signingAlgorithm),
+			Validity: &acmpca.Validity{
+				Type:  aws.String(acmpca.ValidityPeriodTypeDays),
+				Value: aws.Int64(int64(duration.Hours() / 24)),
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("issuing certificate: %w", err)
+	}
+
+	for {
+		var getOut *acmpca.GetCertificateOutput
+		err := instrumentPCACall("GetCertificate", func() error {
+			var err error
+			getOut, err = p.pcaClient.GetCertificateWithContext(ctx, &acmpca.GetCertificateInput{
+				CertificateAuthorityArn: aws.String(p.arn),
+				CertificateArn:          issueOut.CertificateArn,
+			})
+			return err
+		})
+		switch {
+		case err == nil:
+			return []byte(aws.StringValue(getOut.Certificate) + "\n" + aws.StringValue(getOut.CertificateChain)), nil
+		case isRequestInProgress(err):
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(certificatePollInterval):
+			}
+		default:
+			return nil, fmt.Errorf("retrieving issued certificate: %w", err)
+		}
+	}
+}
+
+var (
+	collection = map[types.NamespacedName]*Provisioner{}
+	mutex      sync.RWMutex
+)
+
+// StoreProvisioner registers the Provisioner to be used for the Issuer
+// identified by namespacedName, replacing any Provisioner previously stored
+// for it.
+func StoreProvisioner(namespacedName types.NamespacedName, provisioner *Provisioner) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, existed := collection[namespacedName]; !existed {
+		metrics.ActiveProvisioners.Inc()
+	}
+	collection[namespacedName] = provisioner
+}
+
+// RemoveProvisioner discards the Provisioner registered for the Issuer
+// identified by namespacedName, if any, such as when the Issuer itself is
+// deleted.
+func RemoveProvisioner(namespacedName types.NamespacedName) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, existed := collection[namespacedName]; existed {
+		delete(collection, namespacedName)
+		metrics.ActiveProvisioners.Dec()
+	}
+}
+
+// GetProvisioner returns the Provisioner registered for the Issuer
+// identified by namespacedName, if any.
+func GetProvisioner(namespacedName types.NamespacedName) (*Provisioner, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	provisioner, ok := collection[namespacedName]
+	return provisioner, ok
+}
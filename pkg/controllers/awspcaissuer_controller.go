@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+	core "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// AWSPCAIssuerReconciler reconciles a namespaced AWSPCAIssuer object by
+// delegating to GenericIssuerReconciler.
+type AWSPCAIssuerReconciler struct {
+	GenericIssuerReconciler
+}
+
+// Reconcile fetches the AWSPCAIssuer named by req and hands it to the
+// shared GenericIssuerReconciler.
+func (r *AWSPCAIssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	issuer := new(api.AWSPCAIssuer)
+	if err := r.Client.Get(ctx, req.NamespacedName, issuer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return r.GenericIssuerReconciler.Reconcile(ctx, req, issuer)
+}
+
+// listAWSPCAIssuers lists every AWSPCAIssuer in the cluster as
+// api.GenericIssuer, for use by EnqueueRequestForIssuerFromSecret.
+func (r *AWSPCAIssuerReconciler) listAWSPCAIssuers(ctx context.Context) ([]api.GenericIssuer, error) {
+	list := new(api.AWSPCAIssuerList)
+	if err := r.Client.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	issuers := make([]api.GenericIssuer, len(list.Items))
+	for i := range list.Items {
+		issuers[i] = &list.Items[i]
+	}
+	return issuers, nil
+}
+
+// SetupWithManager sets up the controller with the Manager, including a
+// watch on Secrets so that rotating the credentials referenced by
+// spec.SecretRef re-triggers Reconcile instead of waiting for the next
+// periodic resync.
+func (r *AWSPCAIssuerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.AWSPCAIssuer{}).
+		Watches(&core.Secret{}, handler.EnqueueRequestsFromMapFunc(EnqueueRequestForIssuerFromSecret(r.listAWSPCAIssuers))).
+		Complete(r)
+}
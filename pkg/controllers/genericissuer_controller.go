@@ -21,13 +21,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acmpca"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/logr"
 	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
 	awspca "github.com/jniebuhr/aws-pca-issuer/pkg/aws"
+	"github.com/jniebuhr/aws-pca-issuer/pkg/metrics"
 	"github.com/jniebuhr/aws-pca-issuer/pkg/util"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,15 +42,27 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var (
-	errNoSecretAccessKey = errors.New("no AWS Secret Access Key Found")
-	errNoAccessKeyID     = errors.New("no AWS Access Key ID Found")
-	errNoArnInSpec       = errors.New("no Arn found in Issuer Spec")
-	errNoRegionInSpec    = errors.New("no Region found in Issuer Spec")
+	errNoSecretAccessKey      = errors.New("no AWS Secret Access Key Found")
+	errNoAccessKeyID          = errors.New("no AWS Access Key ID Found")
+	errNoArnInSpec            = errors.New("no Arn found in Issuer Spec")
+	errNoRegionInSpec         = errors.New("no Region found in Issuer Spec")
+	errNoRoleArnForWebID      = errors.New("AWS_WEB_IDENTITY_TOKEN_FILE is set but AWS_ROLE_ARN is not")
+	errEmptyCredentialsSource = errors.New("credentialsSource is set but neither secretsManagerSecretArn nor ssmParameterName is")
+	errAmbientNotAllowed      = errors.New("secretRef or credentialsSource must be set, or allowAmbientCredentials must be true to opt into ambient credentials")
 )
 
+const defaultAssumeRoleSessionName = "aws-pca-issuer"
+
+// credentialsResyncInterval bounds how long a rotated credentials Secret can
+// go unnoticed if, for whatever reason, the Secret watch registered by
+// SetupWithManager misses the update (e.g. the watch cache was momentarily
+// down). The normal path is the watch re-triggering Reconcile immediately.
+const credentialsResyncInterval = 10 * time.Minute
+
 var awsDefaultRegion = os.Getenv("AWS_REGION")
 
 // GenericIssuerReconciler reconciles both AWSPCAIssuer and AWSPCAClusterIssuer objects
@@ -71,11 +89,29 @@ func (r *GenericIssuerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	config := aws.Config{}
+	authMode := "Ambient"
+	var credentialsGeneration string
 
 	if spec.Region != "" {
 		config.Region = aws.String(spec.Region)
 	}
 
+	if spec.Endpoint != "" {
+		config.Endpoint = aws.String(spec.Endpoint)
+	}
+
+	if spec.UseFIPSEndpoint {
+		config.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+
+	if spec.UseDualStackEndpoint {
+		config.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+
+	if spec.DisableSSL {
+		config.DisableSSL = aws.Bool(true)
+	}
+
 	if spec.SecretRef.Name != "" {
 		secretNamespaceName := types.NamespacedName{
 			Namespace: spec.SecretRef.Namespace,
@@ -106,6 +142,57 @@ func (r *GenericIssuerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 
 		config.Credentials = credentials.NewStaticCredentials(string(accessKey), string(secretKey), "")
+		authMode = "StaticCredentials"
+		credentialsGeneration = secret.ResourceVersion
+	} else if spec.CredentialsSource != nil {
+		bootstrapSess, err := session.NewSession(credentialsSourceBootstrapConfig(spec))
+		if err != nil {
+			log.Error(err, "failed to create bootstrap AWS session for CredentialsSource")
+			_ = r.setStatus(ctx, issuer, metav1.ConditionFalse, "SecretsManagerFetchFailed", "Failed to create bootstrap AWS session: %v", err)
+			return ctrl.Result{}, err
+		}
+
+		var refresh time.Duration
+		if spec.CredentialsSource.RefreshInterval != nil {
+			refresh = spec.CredentialsSource.RefreshInterval.Duration
+		}
+
+		var fetched awspca.FetchedCredentials
+		switch {
+		case spec.CredentialsSource.SecretsManagerSecretArn != "":
+			fetched, err = awspca.FetchCredentialsFromSecretsManager(bootstrapSess, spec.CredentialsSource.SecretsManagerSecretArn, refresh)
+			authMode = "SecretsManager"
+		case spec.CredentialsSource.SSMParameterName != "":
+			fetched, err = awspca.FetchCredentialsFromSSM(bootstrapSess, spec.CredentialsSource.SSMParameterName, refresh)
+			authMode = "SSMParameterStore"
+		default:
+			err = errEmptyCredentialsSource
+		}
+		if err != nil {
+			log.Error(err, "failed to fetch credentials from CredentialsSource")
+			_ = r.setStatus(ctx, issuer, metav1.ConditionFalse, "SecretsManagerFetchFailed", "Failed to fetch credentials: %v", err)
+			return ctrl.Result{}, err
+		}
+
+		config.Credentials = credentials.NewStaticCredentials(fetched.AccessKeyID, fetched.SecretAccessKey, "")
+	} else if tokenFile := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); tokenFile != "" {
+		roleArn := os.Getenv("AWS_ROLE_ARN")
+		if roleArn == "" {
+			err := errNoRoleArnForWebID
+			log.Error(err, "failed to configure IRSA credentials")
+			_ = r.setStatus(ctx, issuer, metav1.ConditionFalse, "Error", "Failed to configure IRSA credentials: %v", err)
+			return ctrl.Result{}, err
+		}
+
+		irsaSess, err := session.NewSession(&aws.Config{Region: config.Region})
+		if err != nil {
+			log.Error(err, "failed to create AWS session for IRSA")
+			_ = r.setStatus(ctx, issuer, metav1.ConditionFalse, "Error", "Failed to create AWS session for IRSA: %v", err)
+			return ctrl.Result{}, err
+		}
+
+		config.Credentials = credentials.NewCredentials(stscreds.NewWebIdentityRoleProvider(sts.New(irsaSess), roleArn, "", tokenFile))
+		authMode = "IRSA"
 	}
 
 	sess, err := session.NewSession(&config)
@@ -115,9 +202,72 @@ func (r *GenericIssuerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
-	awspca.StoreProvisioner(req.NamespacedName, awspca.NewProvisioner(sess, spec.Arn))
+	if spec.AssumeRoleArn != "" {
+		sessionName := spec.SessionName
+		if sessionName == "" {
+			sessionName = defaultAssumeRoleSessionName
+		}
+
+		assumeConfig := config
+		assumeConfig.Credentials = stscreds.NewCredentials(sess, spec.AssumeRoleArn, func(p *stscreds.AssumeRoleProvider) {
+			p.RoleSessionName = sessionName
+			if spec.ExternalID != "" {
+				p.ExternalID = aws.String(spec.ExternalID)
+			}
+		})
+
+		sess, err = session.NewSession(&assumeConfig)
+		if err != nil {
+			log.Error(err, "failed to create AWS session for AssumeRole")
+			_ = r.setStatus(ctx, issuer, metav1.ConditionFalse, "Error", "Failed to assume role %s: %v", spec.AssumeRoleArn, err)
+			return ctrl.Result{}, err
+		}
+		authMode += "+AssumeRole"
+	}
+
+	awspca.StoreProvisioner(req.NamespacedName, awspca.NewProvisioner(sess, spec.Arn).WithSecretResourceVersion(credentialsGeneration))
+	issuer.GetStatus().CredentialsGeneration = credentialsGeneration
+	issuer.GetStatus().Endpoint = effectiveEndpoint(sess, spec.Endpoint)
 
-	return ctrl.Result{}, r.setStatus(ctx, issuer, metav1.ConditionTrue, "Verified", "Issuer verified")
+	return ctrl.Result{RequeueAfter: credentialsResyncInterval}, r.setStatus(ctx, issuer, metav1.ConditionTrue, "Verified", "Issuer verified (auth mode: %s)", authMode)
+}
+
+// EnqueueRequestForIssuerFromSecret returns a handler.MapFunc that, given a
+// watched Secret, re-enqueues every Issuer whose SecretRef points at it.
+// listIssuers is supplied by the concrete AWSPCAIssuer/AWSPCAClusterIssuer
+// controller's SetupWithManager, since only it knows which concrete list
+// type to fetch:
+//
+//	Watches(&core.Secret{}, handler.EnqueueRequestsFromMapFunc(
+//	    controllers.EnqueueRequestForIssuerFromSecret(listIssuers)))
+func EnqueueRequestForIssuerFromSecret(listIssuers func(ctx context.Context) ([]api.GenericIssuer, error)) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		secret, ok := obj.(*core.Secret)
+		if !ok {
+			return nil
+		}
+
+		issuers, err := listIssuers(ctx)
+		if err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, issuer := range issuers {
+			ref := issuer.GetSpec().SecretRef
+			secretNamespace := ref.Namespace
+			if secretNamespace == "" {
+				secretNamespace = issuer.GetNamespace()
+			}
+
+			if ref.Name == secret.GetName() && secretNamespace == secret.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: issuer.GetNamespace(), Name: issuer.GetName()},
+				})
+			}
+		}
+		return requests
+	}
 }
 
 func (r *GenericIssuerReconciler) setStatus(ctx context.Context, issuer api.GenericIssuer, status metav1.ConditionStatus, reason, message string, args ...interface{}) error {
@@ -130,16 +280,52 @@ func (r *GenericIssuerReconciler) setStatus(ctx context.Context, issuer api.Gene
 		eventType = core.EventTypeWarning
 	}
 	r.Recorder.Event(issuer, eventType, reason, completeMessage)
+	metrics.IssuerReconcileTotal.WithLabelValues(reason).Inc()
 
 	return r.Client.Status().Update(ctx, issuer)
 }
 
+// credentialsSourceBootstrapConfig returns the aws.Config used to fetch
+// spec.CredentialsSource from Secrets Manager or SSM. Those services are
+// called through their own regional public endpoints, never through the
+// ACM-PCA Endpoint/FIPS/dual-stack override configured for this Issuer, so
+// only the region is carried forward.
+func credentialsSourceBootstrapConfig(spec *api.AWSPCAIssuerSpec) *aws.Config {
+	config := &aws.Config{}
+	if spec.Region != "" {
+		config.Region = aws.String(spec.Region)
+	}
+	return config
+}
+
+// effectiveEndpoint returns the ACM-PCA endpoint the given session will
+// actually send requests to: the explicit override if one was configured,
+// otherwise whatever the SDK resolved from the session's region and
+// FIPS/dual-stack settings.
+func effectiveEndpoint(sess *session.Session, override string) string {
+	if override != "" {
+		return override
+	}
+
+	resolved, err := sess.Config.EndpointResolver.EndpointFor(acmpca.EndpointsID, aws.StringValue(sess.Config.Region), func(o *endpoints.Options) {
+		o.UseFIPSEndpoint = sess.Config.UseFIPSEndpoint
+		o.UseDualStackEndpoint = sess.Config.UseDualStackEndpoint
+		o.DisableSSL = aws.BoolValue(sess.Config.DisableSSL)
+	})
+	if err != nil {
+		return ""
+	}
+	return resolved.URL
+}
+
 func validateIssuer(spec *api.AWSPCAIssuerSpec) error {
 	switch {
 	case spec.Arn == "":
 		return fmt.Errorf(errNoArnInSpec.Error())
 	case spec.Region == "" && awsDefaultRegion == "":
 		return fmt.Errorf(errNoRegionInSpec.Error())
+	case spec.SecretRef.Name == "" && spec.CredentialsSource == nil && !spec.AllowAmbientCredentials:
+		return errAmbientNotAllowed
 	}
 	return nil
 }
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+)
+
+func TestCredentialsSourceBootstrapConfigCarriesOnlyRegion(t *testing.T) {
+	spec := &api.AWSPCAIssuerSpec{
+		Region:               "us-east-1",
+		Endpoint:             "https://vpce-0123456789abcdef-acmpca.us-east-1.vpce.amazonaws.com",
+		UseFIPSEndpoint:      true,
+		UseDualStackEndpoint: true,
+		DisableSSL:           true,
+	}
+
+	config := credentialsSourceBootstrapConfig(spec)
+
+	if got := aws.StringValue(config.Region); got != spec.Region {
+		t.Errorf("Region = %q, want %q", got, spec.Region)
+	}
+	if config.Endpoint != nil {
+		t.Errorf("Endpoint = %q, want nil (Secrets Manager/SSM must not inherit the ACM-PCA endpoint override)", aws.StringValue(config.Endpoint))
+	}
+	if config.UseFIPSEndpoint == endpoints.FIPSEndpointStateEnabled {
+		t.Error("UseFIPSEndpoint = enabled, want unset")
+	}
+	if config.UseDualStackEndpoint == endpoints.DualStackEndpointStateEnabled {
+		t.Error("UseDualStackEndpoint = enabled, want unset")
+	}
+	if config.DisableSSL != nil {
+		t.Error("DisableSSL = set, want nil")
+	}
+}
+
+func TestCredentialsSourceBootstrapConfigNoRegion(t *testing.T) {
+	config := credentialsSourceBootstrapConfig(&api.AWSPCAIssuerSpec{})
+
+	if config.Region != nil {
+		t.Errorf("Region = %q, want nil", aws.StringValue(config.Region))
+	}
+}
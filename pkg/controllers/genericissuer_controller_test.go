@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEnqueueRequestForIssuerFromSecret(t *testing.T) {
+	namespacedIssuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "namespaced", Namespace: "pca"},
+		Spec:       api.AWSPCAIssuerSpec{SecretRef: api.SecretReference{Name: "creds"}},
+	}
+	clusterIssuer := &api.AWSPCAClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       api.AWSPCAIssuerSpec{SecretRef: api.SecretReference{Name: "creds", Namespace: "pca"}},
+	}
+	unrelatedIssuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "pca"},
+		Spec:       api.AWSPCAIssuerSpec{SecretRef: api.SecretReference{Name: "other-creds"}},
+	}
+
+	listIssuers := func(ctx context.Context) ([]api.GenericIssuer, error) {
+		return []api.GenericIssuer{namespacedIssuer, clusterIssuer, unrelatedIssuer}, nil
+	}
+
+	mapFn := EnqueueRequestForIssuerFromSecret(listIssuers)
+	secret := &core.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "pca"}}
+
+	requests := mapFn(context.Background(), secret)
+
+	want := map[types.NamespacedName]bool{
+		{Namespace: "pca", Name: "namespaced"}: true,
+		{Namespace: "", Name: "cluster"}:       true,
+	}
+
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d: %v", len(requests), len(want), requests)
+	}
+	for _, req := range requests {
+		if !want[req.NamespacedName] {
+			t.Errorf("unexpected request %v", req.NamespacedName)
+		}
+	}
+}
+
+func TestEnqueueRequestForIssuerFromSecretIgnoresNonSecrets(t *testing.T) {
+	mapFn := EnqueueRequestForIssuerFromSecret(func(ctx context.Context) ([]api.GenericIssuer, error) {
+		t.Fatal("listIssuers should not be called for a non-Secret object")
+		return nil, nil
+	})
+
+	if requests := mapFn(context.Background(), &core.ConfigMap{}); requests != nil {
+		t.Errorf("got %v, want nil", requests)
+	}
+}
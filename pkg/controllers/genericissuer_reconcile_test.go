@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testArn = "arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"
+
+func newTestReconciler(t *testing.T, objs ...runtime.Object) *GenericIssuerReconciler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := core.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding core scheme: %v", err)
+	}
+	if err := api.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding v1beta1 scheme: %v", err)
+	}
+
+	return &GenericIssuerReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&api.AWSPCAIssuer{}, &api.AWSPCAClusterIssuer{}).
+			WithRuntimeObjects(objs...).
+			Build(),
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+}
+
+func TestReconcileStaticCredentials(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "pca"},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("AKIA..."),
+			"AWS_SECRET_ACCESS_KEY": []byte("secret"),
+		},
+	}
+	issuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: "pca"},
+		Spec: api.AWSPCAIssuerSpec{
+			Arn:       testArn,
+			Region:    "us-east-1",
+			SecretRef: api.SecretReference{Name: "creds", Namespace: "pca"},
+		},
+	}
+
+	r := newTestReconciler(t, secret, issuer)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+
+	if _, err := r.Reconcile(context.Background(), req, issuer); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if issuer.Status.CredentialsGeneration == "" {
+		t.Error("CredentialsGeneration was not recorded from the Secret's resourceVersion")
+	}
+	assertReadyReason(t, issuer, "Verified")
+}
+
+func TestReconcileAssumeRoleKeepsEndpointOverride(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "pca"},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("AKIA..."),
+			"AWS_SECRET_ACCESS_KEY": []byte("secret"),
+		},
+	}
+	issuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: "pca"},
+		Spec: api.AWSPCAIssuerSpec{
+			Arn:           testArn,
+			Region:        "us-east-1",
+			SecretRef:     api.SecretReference{Name: "creds", Namespace: "pca"},
+			AssumeRoleArn: "arn:aws:iam::999999999999:role/cross-account-pca",
+			Endpoint:      "https://vpce-0123456789abcdef-acmpca.us-east-1.vpce.amazonaws.com",
+		},
+	}
+
+	r := newTestReconciler(t, secret, issuer)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+
+	if _, err := r.Reconcile(context.Background(), req, issuer); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if issuer.Status.Endpoint != issuer.Spec.Endpoint {
+		t.Errorf("Status.Endpoint = %q, want %q (the AssumeRole session must keep the endpoint override)", issuer.Status.Endpoint, issuer.Spec.Endpoint)
+	}
+}
+
+func TestReconcileIRSAMissingRoleArn(t *testing.T) {
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	t.Setenv("AWS_ROLE_ARN", "")
+
+	issuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: "pca"},
+		Spec:       api.AWSPCAIssuerSpec{Arn: testArn, Region: "us-east-1", AllowAmbientCredentials: true},
+	}
+
+	r := newTestReconciler(t, issuer)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+
+	if _, err := r.Reconcile(context.Background(), req, issuer); err == nil {
+		t.Fatal("Reconcile() error = nil, want an error for missing AWS_ROLE_ARN")
+	}
+}
+
+func TestReconcileEmptyCredentialsSource(t *testing.T) {
+	issuer := &api.AWSPCAIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: "pca"},
+		Spec: api.AWSPCAIssuerSpec{
+			Arn:               testArn,
+			Region:            "us-east-1",
+			CredentialsSource: &api.CredentialsSource{},
+		},
+	}
+
+	r := newTestReconciler(t, issuer)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(issuer)}
+
+	if _, err := r.Reconcile(context.Background(), req, issuer); err == nil {
+		t.Fatal("Reconcile() error = nil, want an error for an empty credentialsSource")
+	}
+	assertReadyReason(t, issuer, "SecretsManagerFetchFailed")
+}
+
+func assertReadyReason(t *testing.T, issuer *api.AWSPCAIssuer, want string) {
+	t.Helper()
+	for _, cond := range issuer.Status.Conditions {
+		if cond.Type == api.ConditionTypeReady {
+			if cond.Reason != want {
+				t.Errorf("Ready condition reason = %q, want %q", cond.Reason, want)
+			}
+			return
+		}
+	}
+	t.Errorf("no Ready condition found, want reason %q", want)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+)
+
+func TestValidateIssuerRequiresAmbientOptIn(t *testing.T) {
+	tests := map[string]struct {
+		spec    api.AWSPCAIssuerSpec
+		wantErr bool
+	}{
+		"no secretRef, no credentialsSource, no ambient opt-in": {
+			spec:    api.AWSPCAIssuerSpec{Arn: testArn, Region: "us-east-1"},
+			wantErr: true,
+		},
+		"ambient opt-in": {
+			spec:    api.AWSPCAIssuerSpec{Arn: testArn, Region: "us-east-1", AllowAmbientCredentials: true},
+			wantErr: false,
+		},
+		"secretRef set": {
+			spec:    api.AWSPCAIssuerSpec{Arn: testArn, Region: "us-east-1", SecretRef: api.SecretReference{Name: "creds"}},
+			wantErr: false,
+		},
+		"credentialsSource set": {
+			spec:    api.AWSPCAIssuerSpec{Arn: testArn, Region: "us-east-1", CredentialsSource: &api.CredentialsSource{}},
+			wantErr: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateIssuer(&tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validateIssuer() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Prometheus metrics exported by the
+// controller's existing /metrics endpoint, shared between the
+// GenericIssuerReconciler and the awspca.Provisioner.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// IssuerReconcileTotal counts Issuer reconciles by result, e.g.
+	// "Verified" or "Error".
+	IssuerReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "awspca_issuer_reconcile_total",
+		Help: "Total number of AWSPCAIssuer/AWSPCAClusterIssuer reconciles, by result.",
+	}, []string{"result"})
+
+	// PCARequestTotal counts ACM-PCA API calls by operation and AWS error
+	// code, e.g. "Throttling", "AccessDeniedException", "" for success.
+	PCARequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "awspca_pca_request_total",
+		Help: "Total number of ACM-PCA API calls, by operation and error code.",
+	}, []string{"operation", "error_code"})
+
+	// PCARequestDuration observes ACM-PCA signing call latency in seconds.
+	PCARequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "awspca_pca_request_duration_seconds",
+		Help:    "Duration in seconds of ACM-PCA API calls, by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// ActiveProvisioners gauges the number of Issuers with a cached
+	// Provisioner, i.e. Issuers that have reconciled successfully at
+	// least once. It drops only when RemoveProvisioner is called; the
+	// Issuer controllers do not call it when an Issuer is deleted.
+	ActiveProvisioners = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "awspca_active_provisioners",
+		Help: "Number of Issuers with a currently cached ACM-PCA Provisioner.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(IssuerReconcileTotal, PCARequestTotal, PCARequestDuration, ActiveProvisioners)
+}
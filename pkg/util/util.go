@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package util contains small helpers shared across the controllers and
+// webhooks that do not belong to any single API type.
+package util
+
+import (
+	"github.com/go-logr/logr"
+	api "github.com/jniebuhr/aws-pca-issuer/pkg/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetIssuerCondition updates the condition of the given type on the Issuer's
+// status, adding it if it does not already exist. It only bumps
+// LastTransitionTime when the status actually changes.
+func SetIssuerCondition(log logr.Logger, issuer api.GenericIssuer, conditionType api.ConditionType, status metav1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	status_ := issuer.GetStatus()
+
+	for i, cond := range status_.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+
+		if cond.Status != status {
+			log.V(1).Info("found status change for issuer condition; setting lastTransitionTime", "condition", conditionType, "oldStatus", cond.Status, "newStatus", status)
+			status_.Conditions[i].LastTransitionTime = &now
+		}
+
+		status_.Conditions[i].Status = status
+		status_.Conditions[i].Reason = reason
+		status_.Conditions[i].Message = message
+		return
+	}
+
+	status_.Conditions = append(status_.Conditions, api.AWSPCAIssuerCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &now,
+	})
+}